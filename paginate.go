@@ -97,6 +97,10 @@ type PaginatedList[T any] struct {
 	To int `json:"to" bson:"to" xml:"to" yaml:"to"`
 	// Data is the list of items.
 	Data []*T `json:"data" bson:"data" xml:"data" yaml:"data"`
+	// request is the originating request, kept so that Pages can build page
+	// links that preserve any non-pagination query parameters (filters,
+	// sort, ...). It is unexported and therefore never serialized.
+	request *http.Request
 }
 
 // PQ is an interface that defines the methods for queries to be paginated.
@@ -121,16 +125,21 @@ type PQ[I any, Q any] interface {
 // calling this function.
 func GetPage[V any, Q any, T PQ[V, Q]](
 	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+) (*PaginatedList[V], error) {
+	return GetPageWithOptions[V, Q, T](gc, qc, query, params, PageOptions{})
+}
+
+// buildPage runs the `Offset`/`Limit`/`All` query against `query` and
+// assembles the resulting PaginatedList, given an already-known total `count`.
+func buildPage[V any, Q any, T PQ[V, Q]](
+	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+	count int,
 ) (*PaginatedList[V], error) {
 	var next, prev string
 	fi := 1
 	ni := params.Page + 1
 	pi := params.Page - 1
 	req := gc.Request
-	count, err := query.Count(qc)
-	if err != nil {
-		return nil, err
-	}
 	if 0 == count {
 		return &PaginatedList[V]{
 			Total:        0,
@@ -145,6 +154,7 @@ func GetPage[V any, Q any, T PQ[V, Q]](
 			From:         0,
 			To:           0,
 			Data:         []*V{},
+			request:      req,
 		}, nil
 	}
 	from := pi*params.PerPage + 1
@@ -189,6 +199,7 @@ func GetPage[V any, Q any, T PQ[V, Q]](
 		From:         from,
 		To:           to,
 		Data:         rows,
+		request:      req,
 	}, nil
 }
 
@@ -231,6 +242,7 @@ func GetPageMapped[I any, V any, Q any, T PQ[I, Q]](
 		From:         list.From,
 		To:           list.To,
 		Data:         data,
+		request:      list.request,
 	}, nil
 }
 
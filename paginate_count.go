@@ -0,0 +1,233 @@
+package paginate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	eu "github.com/eidng8/go-url"
+	"github.com/gin-gonic/gin"
+)
+
+// CountStrategy selects how GetPageWithOptions (and GetPageMappedWithOptions)
+// obtain the total row count for a query.
+type CountStrategy int
+
+const (
+	// CountExact calls query.Count on every request. This is the default,
+	// and matches GetPage's original behaviour.
+	CountExact CountStrategy = iota
+	// CountCached calls query.Count at most once per
+	// PageOptions.CountCacheKey(query) within PageOptions.CountCacheTTL,
+	// reusing PageOptions.CountCache's value otherwise.
+	CountCached
+	// CountEstimate calls PageOptions.CountEstimator instead of
+	// query.Count, e.g. to read a planner estimate such as PostgreSQL's
+	// `reltuples` rather than running a full `COUNT(*)`.
+	CountEstimate
+	// CountNone skips counting entirely. Total is set to `-1`. LastPage stays
+	// at CurrentPage and LastPageUrl stays empty until the final page is
+	// reached, detected by fetching `PerPage+1` rows as a has-more probe.
+	CountNone
+)
+
+// CountCache is a small in-memory TTL cache of row counts, used by
+// PageOptions.CountCache under CountCached. The zero value is ready to use.
+type CountCache struct {
+	mu      sync.Mutex
+	entries map[string]countCacheEntry
+}
+
+type countCacheEntry struct {
+	count     int
+	expiresAt time.Time
+}
+
+// Get returns the cached count for key, if present and not yet expired.
+func (cc *CountCache) Get(key string) (int, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	entry, ok := cc.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return 0, false
+	}
+	return entry.count, true
+}
+
+// Set stores count for key, to be served back until ttl elapses.
+func (cc *CountCache) Set(key string, count int, ttl time.Duration) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if nil == cc.entries {
+		cc.entries = make(map[string]countCacheEntry)
+	}
+	cc.entries[key] = countCacheEntry{count: count, expiresAt: time.Now().Add(ttl)}
+}
+
+// GetPageWithOptions is GetPage with an additional PageOptions parameter,
+// controlling how the total row count is obtained (see CountStrategy). It
+// sets no response headers itself; the header fields of PageOptions are
+// read by SetPaginationHeaders. When
+// opts.CountStrategy is its zero value, CountExact, it behaves exactly like
+// GetPage.
+//
+// Please remember to explicitly add the `ORDER` clause to the query before
+// calling this function.
+func GetPageWithOptions[V any, Q any, T PQ[V, Q]](
+	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+	opts PageOptions,
+) (*PaginatedList[V], error) {
+	if CountNone == opts.CountStrategy {
+		return buildPageCountless[V, Q, T](gc, qc, query, params)
+	}
+	count, err := countWithStrategy(qc, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return buildPage[V, Q, T](gc, qc, query, params, count)
+}
+
+// GetPageMappedWithOptions is GetPageMapped with an additional PageOptions
+// parameter; see GetPageWithOptions.
+func GetPageMappedWithOptions[I any, V any, Q any, T PQ[I, Q]](
+	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+	opts PageOptions, mapper func(*I, int) *V,
+) (*PaginatedList[V], error) {
+	list, err := GetPageWithOptions[I, Q, T](gc, qc, query, params, opts)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*V, len(list.Data))
+	for i, row := range list.Data {
+		data[i] = mapper(row, i)
+	}
+	return &PaginatedList[V]{
+		Total:        list.Total,
+		PerPage:      list.PerPage,
+		CurrentPage:  list.CurrentPage,
+		LastPage:     list.LastPage,
+		FirstPageUrl: list.FirstPageUrl,
+		LastPageUrl:  list.LastPageUrl,
+		NextPageUrl:  list.NextPageUrl,
+		PrevPageUrl:  list.PrevPageUrl,
+		Path:         list.Path,
+		From:         list.From,
+		To:           list.To,
+		Data:         data,
+		request:      list.request,
+	}, nil
+}
+
+// countWithStrategy obtains the total row count for query, per
+// opts.CountStrategy. It is only called for strategies other than CountNone,
+// which is handled separately by buildPageCountless.
+func countWithStrategy[V any, Q any, T PQ[V, Q]](
+	qc context.Context, query T, opts PageOptions,
+) (int, error) {
+	switch opts.CountStrategy {
+	case CountCached:
+		if nil == opts.CountCacheKey {
+			return 0, fmt.Errorf(
+				"paginate: PageOptions.CountCacheKey is required for CountCached",
+			)
+		}
+		key := opts.CountCacheKey(query)
+		cache := opts.CountCache
+		if nil == cache {
+			cache = &CountCache{}
+		}
+		if count, ok := cache.Get(key); ok {
+			return count, nil
+		}
+		count, err := query.Count(qc)
+		if err != nil {
+			return 0, err
+		}
+		cache.Set(key, count, opts.CountCacheTTL)
+		return count, nil
+	case CountEstimate:
+		if nil == opts.CountEstimator {
+			return 0, fmt.Errorf(
+				"paginate: PageOptions.CountEstimator is required for CountEstimate",
+			)
+		}
+		return opts.CountEstimator(qc)
+	default:
+		return query.Count(qc)
+	}
+}
+
+// buildPageCountless assembles a PaginatedList without ever calling
+// query.Count, by fetching `PerPage+1` rows and using the extra row to
+// detect whether a next page exists. Total is always `-1`; LastPage equals
+// CurrentPage until the final page is reached.
+func buildPageCountless[V any, Q any, T PQ[V, Q]](
+	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+) (*PaginatedList[V], error) {
+	req := gc.Request
+	pi := params.Page - 1
+	query.Offset(pi * params.PerPage)
+	query.Limit(params.PerPage + 1)
+	rows, err := query.All(qc)
+	if err != nil {
+		return nil, err
+	}
+	hasNext := len(rows) > params.PerPage
+	if hasNext {
+		rows = rows[:params.PerPage]
+	}
+	if 0 == len(rows) {
+		var prev string
+		if pi >= 1 {
+			prev = UrlWithPage(req, pi, params.PerPage).String()
+		}
+		var last string
+		if params.Page > 1 {
+			last = UrlWithPage(req, params.Page, params.PerPage).String()
+		}
+		return &PaginatedList[V]{
+			Total:        -1,
+			PerPage:      params.PerPage,
+			CurrentPage:  params.Page,
+			LastPage:     params.Page,
+			FirstPageUrl: UrlWithPage(req, 1, params.PerPage).String(),
+			LastPageUrl:  last,
+			PrevPageUrl:  prev,
+			Path:         eu.RequestBaseUrl(req).String(),
+			From:         0,
+			To:           0,
+			Data:         []*V{},
+			request:      req,
+		}, nil
+	}
+	li := params.Page
+	var last, next string
+	if hasNext {
+		next = UrlWithPage(req, params.Page+1, params.PerPage).String()
+	} else if params.Page > 1 {
+		last = UrlWithPage(req, params.Page, params.PerPage).String()
+	}
+	var prev string
+	if pi >= 1 {
+		prev = UrlWithPage(req, pi, params.PerPage).String()
+	}
+	from := pi*params.PerPage + 1
+	to := from + len(rows) - 1
+	return &PaginatedList[V]{
+		Total:        -1,
+		PerPage:      params.PerPage,
+		CurrentPage:  params.Page,
+		LastPage:     int(math.Max(float64(li), 1)),
+		FirstPageUrl: UrlWithPage(req, 1, params.PerPage).String(),
+		LastPageUrl:  last,
+		NextPageUrl:  next,
+		PrevPageUrl:  prev,
+		Path:         eu.RequestBaseUrl(req).String(),
+		From:         from,
+		To:           to,
+		Data:         rows,
+		request:      req,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package paginate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCountCacheGetMiss(t *testing.T) {
+	var cache CountCache
+	if _, ok := cache.Get("missing"); ok {
+		t.Fatal("Get on empty cache expected a miss, got a hit")
+	}
+}
+
+func TestCountCacheSetThenGetBeforeExpiry(t *testing.T) {
+	var cache CountCache
+	cache.Set("key", 42, time.Hour)
+	count, ok := cache.Get("key")
+	if !ok {
+		t.Fatal("Get expected a hit right after Set, got a miss")
+	}
+	if 42 != count {
+		t.Fatalf("Get() = %d, want 42", count)
+	}
+}
+
+func TestCountCacheExpires(t *testing.T) {
+	var cache CountCache
+	cache.Set("key", 42, -time.Second)
+	if _, ok := cache.Get("key"); ok {
+		t.Fatal("Get after TTL elapsed expected a miss, got a hit")
+	}
+}
+
+func TestCountWithStrategyExact(t *testing.T) {
+	q := newFakeQuery(5)
+	count, err := countWithStrategy(
+		context.Background(), q, PageOptions{CountStrategy: CountExact},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if 5 != count {
+		t.Fatalf("countWithStrategy() = %d, want 5", count)
+	}
+}
+
+func TestCountWithStrategyCachedMissingKey(t *testing.T) {
+	q := newFakeQuery(5)
+	_, err := countWithStrategy(
+		context.Background(), q, PageOptions{CountStrategy: CountCached},
+	)
+	if err == nil {
+		t.Fatal("expected an error when PageOptions.CountCacheKey is nil, got nil")
+	}
+}
+
+func TestCountWithStrategyCachedMissThenHit(t *testing.T) {
+	q := newFakeQuery(5)
+	var cache CountCache
+	calls := 0
+	opts := PageOptions{
+		CountStrategy: CountCached,
+		CountCache:    &cache,
+		CountCacheTTL: time.Hour,
+		CountCacheKey: func(any) string { calls++; return "items" },
+	}
+	count, err := countWithStrategy(context.Background(), q, opts)
+	if err != nil {
+		t.Fatalf("unexpected error on miss: %v", err)
+	}
+	if 5 != count {
+		t.Fatalf("countWithStrategy() = %d, want 5", count)
+	}
+	// Change the underlying data; a cache hit must still return the stale
+	// cached value rather than recomputing.
+	q.all = append(q.all, &fakeRow{ID: 6})
+	count, err = countWithStrategy(context.Background(), q, opts)
+	if err != nil {
+		t.Fatalf("unexpected error on hit: %v", err)
+	}
+	if 5 != count {
+		t.Fatalf("countWithStrategy() on cache hit = %d, want 5 (stale)", count)
+	}
+}
+
+func TestCountWithStrategyEstimateMissingEstimator(t *testing.T) {
+	q := newFakeQuery(5)
+	_, err := countWithStrategy(
+		context.Background(), q, PageOptions{CountStrategy: CountEstimate},
+	)
+	if err == nil {
+		t.Fatal(
+			"expected an error when PageOptions.CountEstimator is nil, got nil",
+		)
+	}
+}
+
+func TestCountWithStrategyEstimate(t *testing.T) {
+	q := newFakeQuery(5)
+	opts := PageOptions{
+		CountStrategy: CountEstimate,
+		CountEstimator: func(context.Context) (int, error) {
+			return 42, nil
+		},
+	}
+	count, err := countWithStrategy(context.Background(), q, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if 42 != count {
+		t.Fatalf("countWithStrategy() = %d, want 42", count)
+	}
+}
+
+func TestGetPageWithOptionsCountNoneOvershoot(t *testing.T) {
+	list, err := GetPageWithOptions[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items?page=50&per_page=2"),
+		context.Background(), newFakeQuery(5),
+		PaginatedParams{Page: 50, PerPage: 2}, PageOptions{CountStrategy: CountNone},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if -1 != list.Total {
+		t.Fatalf("Total = %d, want -1 under CountNone", list.Total)
+	}
+	if 50 != list.CurrentPage {
+		t.Fatalf("CurrentPage = %d, want 50 (the requested page)", list.CurrentPage)
+	}
+	if 50 != list.LastPage {
+		t.Fatalf("LastPage = %d, want 50", list.LastPage)
+	}
+	if "" == list.PrevPageUrl {
+		t.Fatal("expected a PrevPageUrl since page 50 is beyond the data")
+	}
+	if 0 != len(list.Data) {
+		t.Fatalf("Data = %v, want empty", list.Data)
+	}
+}
+
+func TestGetPageWithOptionsCountNoneHasNext(t *testing.T) {
+	list, err := GetPageWithOptions[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items?page=1&per_page=2"),
+		context.Background(), newFakeQuery(5),
+		PaginatedParams{Page: 1, PerPage: 2}, PageOptions{CountStrategy: CountNone},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := cursorIDs(list.Data), []int{1, 2}; !equalInts(got, want) {
+		t.Fatalf("Data = %v, want %v", got, want)
+	}
+	if "" == list.NextPageUrl {
+		t.Fatal("expected a NextPageUrl, more rows remain")
+	}
+}
@@ -0,0 +1,286 @@
+package paginate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	eu "github.com/eidng8/go-url"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ParamCursor is the query parameter name for the pagination cursor.
+	ParamCursor = "cursor"
+
+	// ParamDirection is the query parameter name for the cursor direction.
+	ParamDirection = "direction"
+
+	// DirectionNext requests the page following the cursor. This is the
+	// default direction.
+	DirectionNext = "next"
+
+	// DirectionPrev requests the page preceding the cursor.
+	DirectionPrev = "prev"
+)
+
+// CursorParams is a struct that contains the parameters used by keyset
+// (cursor) pagination.
+type CursorParams struct {
+	// Cursor is the opaque token identifying the last seen row. It is empty
+	// for the first page.
+	Cursor string `form:"cursor"`
+	// PerPage is the number of items per page.
+	PerPage int `form:"per_page"`
+	// Direction indicates whether to fetch the page after (`next`) or before
+	// (`prev`) the cursor.
+	Direction string `form:"direction"`
+}
+
+// GetPerPage returns the number of items per page.
+func (cp *CursorParams) GetPerPage() int {
+	if cp.PerPage < 1 {
+		return 10
+	}
+	return cp.PerPage
+}
+
+// GetDirection returns the cursor direction, defaulting to DirectionNext.
+func (cp *CursorParams) GetDirection() string {
+	if DirectionPrev == cp.Direction {
+		return DirectionPrev
+	}
+	return DirectionNext
+}
+
+// GetCursorParams returns the CursorParams from the gin.Context, with a
+// default value of `10` items per page.
+func GetCursorParams(gc *gin.Context) CursorParams {
+	return GetCursorParamsWithDefault(gc, 10)
+}
+
+// GetCursorParamsWithDefault returns the CursorParams from the gin.Context
+// with the given default per-page value.
+func GetCursorParamsWithDefault(
+	gc *gin.Context, defaultPerPage int,
+) CursorParams {
+	var params CursorParams
+	if gc.ShouldBind(&params) != nil {
+		params.PerPage = defaultPerPage
+	}
+	if params.PerPage < 1 {
+		params.PerPage = defaultPerPage
+	}
+	params.Direction = params.GetDirection()
+	return params
+}
+
+// CursorDescriptor describes the ordering column(s) a cursor is based on,
+// and how to turn a decoded cursor back into a keyset predicate.
+type CursorDescriptor[V any, Q any] struct {
+	// Columns lists the ordering column names, most significant first. They
+	// are descriptive only; the actual comparison is performed by After and
+	// Before.
+	Columns []string
+	// Extract returns the ordering column values of the given row, in the
+	// same order as Columns. These values are opaquely encoded into the
+	// cursor token.
+	Extract func(row *V) []any
+	// After returns a query predicate equivalent to
+	// `WHERE (col1, col2, ...) > (v1, v2, ...)` for the given decoded cursor
+	// values, to be passed to CursorPQ.Where when paginating forward.
+	After func(values []any) func(*Q)
+	// Before returns the reverse of After, equivalent to
+	// `WHERE (col1, col2, ...) < (v1, v2, ...)`, to be passed to
+	// CursorPQ.Where when paginating backward (direction=`prev`). Required
+	// only when GetPageCursor is called with a backward CursorParams.
+	Before func(values []any) func(*Q)
+}
+
+// CursorPQ is an interface that defines the methods for queries to be
+// paginated using a keyset cursor.
+type CursorPQ[I any, Q any] interface {
+	PQ[I, Q]
+
+	// Where applies the given predicate to the query.
+	Where(predicate func(*Q)) *Q
+
+	// Reverse flips the query's `ORDER BY` direction on every cursor column.
+	// It is called when paginating backward (direction=`prev`), so that
+	// `Limit`/`Before` fetch the rows immediately preceding the cursor
+	// rather than the rows nearest the start of the table.
+	Reverse() *Q
+}
+
+// PaginatedCursorList is a struct that contains a keyset (cursor) paginated
+// list of items.
+type PaginatedCursorList[T any] struct {
+	// Total is the total number of items. It is only populated when
+	// explicitly requested via GetPageCursor's withTotal parameter, since
+	// COUNT is the expensive part on huge tables. It is `-1` otherwise.
+	Total int `json:"total" bson:"total" xml:"total" yaml:"total"`
+	// PerPage is the number of items per page.
+	PerPage int `json:"per_page" bson:"per_page" xml:"per_page" yaml:"per_page"`
+	// NextCursorUrl is the URL of the next page. It is an empty string if
+	// there are no more items after the current page.
+	NextCursorUrl string `json:"next_cursor_url" bson:"next_cursor_url" xml:"next_cursor_url" yaml:"next_cursor_url"`
+	// PrevCursorUrl is the URL of the previous page. It is an empty string if
+	// the current page is the first page.
+	PrevCursorUrl string `json:"prev_cursor_url" bson:"prev_cursor_url" xml:"prev_cursor_url" yaml:"prev_cursor_url"`
+	// Path is the fully qualified URL without query string.
+	Path string `json:"path" bson:"path" xml:"path" yaml:"path"`
+	// Data is the list of items.
+	Data []*T `json:"data" bson:"data" xml:"data" yaml:"data"`
+}
+
+// GetPageCursor returns a keyset (cursor) paginated list of items. `V` is the
+// type of items in the paginated list. `Q` is the query type to be used to
+// retrieve items, which in most cases can be inferred.
+//
+// The `gc` parameter is the gin.Context used to generate the page URLs; `qc`
+// is the context used in query execution; `query` is the query instance to be
+// executed; `params` is the CursorParams to be used in pagination; `desc`
+// describes the ordering column(s) the cursor is based on; and `withTotal`
+// requests an additional `COUNT` query to populate PaginatedCursorList.Total.
+//
+// When `params.GetDirection()` is DirectionPrev, the query is reversed via
+// CursorPQ.Reverse and filtered with desc.Before, so the rows immediately
+// preceding the cursor are fetched; the fetched rows are then restored to
+// the original ascending order before being returned.
+//
+// Please remember to explicitly add the `ORDER` clause, matching desc.Columns,
+// to the query before calling this function.
+func GetPageCursor[V any, Q any, T CursorPQ[V, Q]](
+	gc *gin.Context, qc context.Context, query T, params CursorParams,
+	desc CursorDescriptor[V, Q], withTotal bool,
+) (*PaginatedCursorList[V], error) {
+	req := gc.Request
+	perPage := params.GetPerPage()
+	backward := "" != params.Cursor && DirectionPrev == params.GetDirection()
+	total := -1
+	if withTotal {
+		var err error
+		if total, err = query.Count(qc); err != nil {
+			return nil, err
+		}
+	}
+	if "" != params.Cursor {
+		values, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if backward {
+			if nil == desc.Before {
+				return nil, fmt.Errorf(
+					"paginate: CursorDescriptor.Before is required for backward pagination",
+				)
+			}
+			query.Reverse()
+			query.Where(desc.Before(values))
+		} else {
+			query.Where(desc.After(values))
+		}
+	}
+	query.Limit(perPage + 1)
+	rows, err := query.All(qc)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(rows) > perPage
+	if hasMore {
+		rows = rows[:perPage]
+	}
+	if backward {
+		reverseRows(rows)
+	}
+	var next, prev string
+	if backward {
+		if hasMore && 0 < len(rows) {
+			prev = UrlWithCursor(
+				req, encodeCursor(desc.Extract(rows[0])), perPage,
+				DirectionPrev,
+			).String()
+		}
+		if 0 < len(rows) {
+			next = UrlWithCursor(
+				req, encodeCursor(desc.Extract(rows[len(rows)-1])), perPage,
+				DirectionNext,
+			).String()
+		}
+	} else {
+		if hasMore && 0 < len(rows) {
+			next = UrlWithCursor(
+				req, encodeCursor(desc.Extract(rows[len(rows)-1])), perPage,
+				DirectionNext,
+			).String()
+		}
+		if "" != params.Cursor && 0 < len(rows) {
+			prev = UrlWithCursor(
+				req, encodeCursor(desc.Extract(rows[0])), perPage,
+				DirectionPrev,
+			).String()
+		}
+	}
+	return &PaginatedCursorList[V]{
+		Total:         total,
+		PerPage:       perPage,
+		NextCursorUrl: next,
+		PrevCursorUrl: prev,
+		Path:          eu.RequestBaseUrl(req).String(),
+		Data:          rows,
+	}, nil
+}
+
+// reverseRows reverses rows in place.
+func reverseRows[V any](rows []*V) {
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+}
+
+// UrlWithCursor returns a URL with the cursor, per_page, and direction query
+// parameters set.
+func UrlWithCursor(
+	request *http.Request, cursor string, perPage int, direction string,
+) *url.URL {
+	return eu.RequestUrlWithQueryParams(
+		request, CursorQueryParams(cursor, perPage, direction),
+	)
+}
+
+// CursorQueryParams sets the cursor, per_page, and direction query
+// parameters.
+func CursorQueryParams(
+	cursor string, perPage int, direction string,
+) map[string]string {
+	params := make(map[string]string, 3)
+	params[ParamCursor] = cursor
+	params[ParamPerPage] = fmt.Sprintf("%d", perPage)
+	params[ParamDirection] = direction
+	return params
+}
+
+// encodeCursor opaquely encodes a tuple of ordering column values as a
+// base64 cursor token.
+func encodeCursor(values []any) string {
+	b, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes a cursor token back into a tuple of ordering column
+// values. Note that, as with any JSON decoding, numeric values are decoded as
+// float64.
+func decodeCursor(cursor string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var values []any
+	if err = json.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
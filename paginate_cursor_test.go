@@ -0,0 +1,214 @@
+package paginate
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func cursorIDs(rows []*fakeRow) []int {
+	ids := make([]int, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func cursorFromUrl(t *testing.T, rawURL string) string {
+	t.Helper()
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) returned error: %v", rawURL, err)
+	}
+	return parsed.Query().Get(ParamCursor)
+}
+
+func TestGetPageCursorForwardAndBackward(t *testing.T) {
+	desc := fakeCursorDescriptor()
+	ctx := context.Background()
+
+	// Page 1: no cursor yet.
+	page1, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{PerPage: 2}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("page 1: unexpected error: %v", err)
+	}
+	if got, want := cursorIDs(page1.Data), []int{1, 2}; !equalInts(got, want) {
+		t.Fatalf("page 1 data = %v, want %v", got, want)
+	}
+	if "" == page1.NextCursorUrl {
+		t.Fatal("page 1: expected a NextCursorUrl")
+	}
+	if "" != page1.PrevCursorUrl {
+		t.Fatal("page 1: expected no PrevCursorUrl on the first page")
+	}
+
+	// Page 2: follow page 1's next cursor.
+	page2, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{
+			PerPage: 2, Cursor: cursorFromUrl(t, page1.NextCursorUrl),
+			Direction: DirectionNext,
+		}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("page 2: unexpected error: %v", err)
+	}
+	if got, want := cursorIDs(page2.Data), []int{3, 4}; !equalInts(got, want) {
+		t.Fatalf("page 2 data = %v, want %v", got, want)
+	}
+	if "" == page2.NextCursorUrl {
+		t.Fatal("page 2: expected a NextCursorUrl")
+	}
+	if "" == page2.PrevCursorUrl {
+		t.Fatal("page 2: expected a PrevCursorUrl")
+	}
+
+	// Page 3: follow page 2's next cursor; this is the last page.
+	page3, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{
+			PerPage: 2, Cursor: cursorFromUrl(t, page2.NextCursorUrl),
+			Direction: DirectionNext,
+		}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("page 3: unexpected error: %v", err)
+	}
+	if got, want := cursorIDs(page3.Data), []int{5}; !equalInts(got, want) {
+		t.Fatalf("page 3 data = %v, want %v", got, want)
+	}
+	if "" != page3.NextCursorUrl {
+		t.Fatal("page 3: expected no NextCursorUrl on the last page")
+	}
+
+	// Now walk backward from page 2's prev cursor; this must return page 1
+	// again, not repeat page 2 or skip ahead.
+	back, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{
+			PerPage: 2, Cursor: cursorFromUrl(t, page2.PrevCursorUrl),
+			Direction: DirectionPrev,
+		}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("backward page: unexpected error: %v", err)
+	}
+	if got, want := cursorIDs(back.Data), []int{1, 2}; !equalInts(got, want) {
+		t.Fatalf("backward page data = %v, want %v", got, want)
+	}
+	if "" != back.PrevCursorUrl {
+		t.Fatal("backward page: expected no PrevCursorUrl, it is the first page")
+	}
+}
+
+func TestGetPageCursorWithTotalIgnoresKeysetFilter(t *testing.T) {
+	desc := fakeCursorDescriptor()
+	ctx := context.Background()
+	page1, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{PerPage: 2}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("page 1: unexpected error: %v", err)
+	}
+	page2, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{
+			PerPage: 2, Cursor: cursorFromUrl(t, page1.NextCursorUrl),
+			Direction: DirectionNext,
+		}, desc, true,
+	)
+	if err != nil {
+		t.Fatalf("page 2: unexpected error: %v", err)
+	}
+	if 5 != page2.Total {
+		t.Fatalf(
+			"page 2 Total = %d, want 5 (the unfiltered row count)",
+			page2.Total,
+		)
+	}
+}
+
+func TestGetPageCursorBackwardRequiresBefore(t *testing.T) {
+	desc := fakeCursorDescriptor()
+	desc.Before = nil
+	ctx := context.Background()
+	page1, err := GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{PerPage: 2}, desc, false,
+	)
+	if err != nil {
+		t.Fatalf("page 1: unexpected error: %v", err)
+	}
+	_, err = GetPageCursor[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), ctx, newFakeQuery(5),
+		CursorParams{
+			PerPage: 2, Cursor: cursorFromUrl(t, page1.NextCursorUrl),
+			Direction: DirectionPrev,
+		}, desc, false,
+	)
+	if err == nil {
+		t.Fatal(
+			"expected an error when CursorDescriptor.Before is nil and " +
+				"direction=prev, got nil",
+		)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEncodeDecodeCursorRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []any
+	}{
+		{"empty", []any{}},
+		{"single string", []any{"foo"}},
+		{"number", []any{float64(42)}},
+		{"composite", []any{float64(42), "foo", true}},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				token := encodeCursor(tc.values)
+				values, err := decodeCursor(token)
+				if err != nil {
+					t.Fatalf("decodeCursor(%q) returned error: %v", token, err)
+				}
+				if len(values) != len(tc.values) {
+					t.Fatalf(
+						"decodeCursor(%q) = %v, want %v", token, values,
+						tc.values,
+					)
+				}
+				for i := range values {
+					if values[i] != tc.values[i] {
+						t.Fatalf(
+							"decodeCursor(%q)[%d] = %v, want %v", token, i,
+							values[i], tc.values[i],
+						)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestDecodeCursorInvalidToken(t *testing.T) {
+	if _, err := decodeCursor("not-base64!!"); err == nil {
+		t.Fatal("decodeCursor with invalid base64 expected an error, got nil")
+	}
+}
@@ -0,0 +1,85 @@
+package paginate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PageOptions controls optional behaviour of GetPageWithOptions and
+// GetPageMappedWithOptions (how the total row count is obtained) and of
+// SetPaginationHeaders (HTTP header emission).
+type PageOptions struct {
+	// EmitLinkHeader, when true, sets the RFC 8288 `Link` response header
+	// with `first`, `prev`, `next`, and `last` relations.
+	EmitLinkHeader bool
+	// HeaderPrefix is the prefix used for the `X-Total-Count`, `X-Page`,
+	// `X-Per-Page`, and `X-Total-Pages` response headers. It defaults to `X`
+	// when empty.
+	HeaderPrefix string
+	// CountStrategy selects how the total row count is obtained. It defaults
+	// to CountExact.
+	CountStrategy CountStrategy
+	// CountCache is the cache consulted and populated by CountCached. It may
+	// be shared across requests; its zero value is ready to use. When nil,
+	// each call uses a fresh, empty cache, so no count is reused.
+	CountCache *CountCache
+	// CountCacheTTL is how long a CountCached count is reused for the same
+	// CountCacheKey(query) before query.Count is called again.
+	CountCacheTTL time.Duration
+	// CountCacheKey returns the CountCache key for the given query, e.g.
+	// derived from its filters. Required when CountStrategy is CountCached.
+	CountCacheKey func(query any) string
+	// CountEstimator returns an estimated row count, e.g. read from a
+	// planner statistic such as PostgreSQL's `reltuples`, instead of running
+	// `query.Count`. Required when CountStrategy is CountEstimate.
+	CountEstimator func(qc context.Context) (int, error)
+}
+
+// GetHeaderPrefix returns the configured header prefix, defaulting to `X`.
+func (po *PageOptions) GetHeaderPrefix() string {
+	if "" == po.HeaderPrefix {
+		return "X"
+	}
+	return po.HeaderPrefix
+}
+
+// SetPaginationHeaders sets the `X-Total-Count`, `X-Page`, `X-Per-Page`, and
+// `X-Total-Pages` headers (using opts.HeaderPrefix in place of `X`), and,
+// when opts.EmitLinkHeader is true, the RFC 5988/8288 `Link` header, on gc's
+// response. It is typically called right after GetPage or GetPageMapped.
+func SetPaginationHeaders[T any](
+	gc *gin.Context, list *PaginatedList[T], opts PageOptions,
+) {
+	prefix := opts.GetHeaderPrefix()
+	gc.Header(prefix+"-Total-Count", strconv.Itoa(list.Total))
+	gc.Header(prefix+"-Page", strconv.Itoa(list.CurrentPage))
+	gc.Header(prefix+"-Per-Page", strconv.Itoa(list.PerPage))
+	gc.Header(prefix+"-Total-Pages", strconv.Itoa(list.LastPage))
+	if opts.EmitLinkHeader {
+		if link := LinkHeader(list); "" != link {
+			gc.Header("Link", link)
+		}
+	}
+}
+
+// LinkHeader builds the RFC 5988/8288 `Link` header value for the given
+// PaginatedList, e.g. `<url>; rel="first", <url>; rel="next"`. Relations
+// whose URL is empty (such as `next` on the last page) are omitted.
+func LinkHeader[T any](list *PaginatedList[T]) string {
+	links := make([]string, 0, 4)
+	add := func(url, rel string) {
+		if "" != url {
+			links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, url, rel))
+		}
+	}
+	add(list.FirstPageUrl, "first")
+	add(list.PrevPageUrl, "prev")
+	add(list.NextPageUrl, "next")
+	add(list.LastPageUrl, "last")
+	return strings.Join(links, ", ")
+}
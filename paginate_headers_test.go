@@ -0,0 +1,55 @@
+package paginate
+
+import "testing"
+
+func TestLinkHeader(t *testing.T) {
+	cases := []struct {
+		name string
+		list *PaginatedList[struct{}]
+		want string
+	}{
+		{
+			name: "all relations",
+			list: &PaginatedList[struct{}]{
+				FirstPageUrl: "https://example.com/?page=1",
+				PrevPageUrl:  "https://example.com/?page=2",
+				NextPageUrl:  "https://example.com/?page=4",
+				LastPageUrl:  "https://example.com/?page=5",
+			},
+			want: `<https://example.com/?page=1>; rel="first", ` +
+				`<https://example.com/?page=2>; rel="prev", ` +
+				`<https://example.com/?page=4>; rel="next", ` +
+				`<https://example.com/?page=5>; rel="last"`,
+		},
+		{
+			name: "first page only",
+			list: &PaginatedList[struct{}]{
+				FirstPageUrl: "https://example.com/?page=1",
+				NextPageUrl:  "https://example.com/?page=2",
+			},
+			want: `<https://example.com/?page=1>; rel="first", ` +
+				`<https://example.com/?page=2>; rel="next"`,
+		},
+		{
+			name: "single page",
+			list: &PaginatedList[struct{}]{
+				FirstPageUrl: "https://example.com/?page=1",
+			},
+			want: `<https://example.com/?page=1>; rel="first"`,
+		},
+		{
+			name: "empty",
+			list: &PaginatedList[struct{}]{},
+			want: "",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				if got := LinkHeader(tc.list); got != tc.want {
+					t.Fatalf("LinkHeader() = %q, want %q", got, tc.want)
+				}
+			},
+		)
+	}
+}
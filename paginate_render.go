@@ -0,0 +1,125 @@
+package paginate
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MimeJSON is the standard JSON media type.
+	MimeJSON = "application/json"
+	// MimeXML is the standard XML media type.
+	MimeXML = "application/xml"
+	// MimeYAML is the standard YAML media type.
+	MimeYAML = "application/x-yaml"
+	// MimeHAL is the HAL+JSON media type.
+	MimeHAL = "application/hal+json"
+	// MimeJSONAPI is the JSON:API media type.
+	MimeJSONAPI = "application/vnd.api+json"
+)
+
+// halLink is a single entry of a HalList's `_links` object.
+type halLink struct {
+	Href string `json:"href"`
+}
+
+// HalList is the `application/hal+json` envelope for a PaginatedList.
+type HalList[T any] struct {
+	Links       map[string]halLink `json:"_links"`
+	Total       int                `json:"total"`
+	PerPage     int                `json:"per_page"`
+	CurrentPage int                `json:"current_page"`
+	LastPage    int                `json:"last_page"`
+	Embedded    struct {
+		Data []*T `json:"data"`
+	} `json:"_embedded"`
+}
+
+// JSONAPIList is the `application/vnd.api+json` envelope for a
+// PaginatedList.
+type JSONAPIList[T any] struct {
+	Data  []*T `json:"data"`
+	Links struct {
+		Self  string `json:"self"`
+		First string `json:"first,omitempty"`
+		Prev  string `json:"prev,omitempty"`
+		Next  string `json:"next,omitempty"`
+		Last  string `json:"last,omitempty"`
+	} `json:"links"`
+	Meta struct {
+		Total   int `json:"total"`
+		PerPage int `json:"per-page"`
+	} `json:"meta"`
+}
+
+// Render inspects gc's `Accept` header and writes `list` to the response in
+// the negotiated format: `application/json` (the default), `application/xml`,
+// `application/x-yaml`, `application/hal+json`, or
+// `application/vnd.api+json`.
+func Render[T any](gc *gin.Context, list *PaginatedList[T]) {
+	switch gc.NegotiateFormat(MimeJSON, MimeXML, MimeYAML, MimeHAL, MimeJSONAPI) {
+	case MimeXML:
+		gc.XML(http.StatusOK, list)
+	case MimeYAML:
+		gc.YAML(http.StatusOK, list)
+	case MimeHAL:
+		renderJSONAs(gc, MimeHAL, toHAL(list))
+	case MimeJSONAPI:
+		renderJSONAs(gc, MimeJSONAPI, toJSONAPI(list))
+	default:
+		gc.JSON(http.StatusOK, list)
+	}
+}
+
+// renderJSONAs marshals body as JSON and writes it to gc's response with the
+// given Content-Type, instead of the `application/json` that gc.JSON always
+// sets, so media types such as `application/hal+json` are correctly labeled.
+func renderJSONAs(gc *gin.Context, contentType string, body any) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		_ = gc.Error(err)
+		gc.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	gc.Data(http.StatusOK, contentType, b)
+}
+
+// toHAL converts a PaginatedList into its HalList envelope.
+func toHAL[T any](list *PaginatedList[T]) HalList[T] {
+	var hal HalList[T]
+	hal.Links = map[string]halLink{"self": {Href: list.Path}}
+	if "" != list.FirstPageUrl {
+		hal.Links["first"] = halLink{Href: list.FirstPageUrl}
+	}
+	if "" != list.PrevPageUrl {
+		hal.Links["prev"] = halLink{Href: list.PrevPageUrl}
+	}
+	if "" != list.NextPageUrl {
+		hal.Links["next"] = halLink{Href: list.NextPageUrl}
+	}
+	if "" != list.LastPageUrl {
+		hal.Links["last"] = halLink{Href: list.LastPageUrl}
+	}
+	hal.Total = list.Total
+	hal.PerPage = list.PerPage
+	hal.CurrentPage = list.CurrentPage
+	hal.LastPage = list.LastPage
+	hal.Embedded.Data = list.Data
+	return hal
+}
+
+// toJSONAPI converts a PaginatedList into its JSONAPIList envelope.
+func toJSONAPI[T any](list *PaginatedList[T]) JSONAPIList[T] {
+	var doc JSONAPIList[T]
+	doc.Data = list.Data
+	doc.Links.Self = list.Path
+	doc.Links.First = list.FirstPageUrl
+	doc.Links.Prev = list.PrevPageUrl
+	doc.Links.Next = list.NextPageUrl
+	doc.Links.Last = list.LastPageUrl
+	doc.Meta.Total = list.Total
+	doc.Meta.PerPage = list.PerPage
+	return doc
+}
@@ -0,0 +1,87 @@
+package paginate
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxStreamPages is the default upper bound on the number of pages
+// StreamAllPages will fetch, used when the `maxPages` argument is zero or
+// negative.
+const DefaultMaxStreamPages = 10000
+
+// StreamAllPages iterates through all pages of `query`, `PerPage` rows at a
+// time, and writes a single merged JSON array of all rows to `writer`,
+// without materializing the whole result set in memory. This lets a client
+// that asked for every row (e.g. via a `?paginate=all` parameter) receive one
+// well-formed JSON document, while the server still issues bounded queries of
+// `PerPage` rows.
+//
+// `V` is the type of items being streamed. `Q` is the query type to be used
+// to retrieve items, which in most cases can be inferred.
+//
+// The `gc` parameter is used to set the response `Content-Type` to MimeJSON,
+// the same way Render labels its default format; `qc` is the context used in
+// query execution, cancellation of which (e.g. because the client
+// disconnected) halts further DB traffic; `query` is the query instance to be
+// executed; `params` is used for its `PerPage` value; `writer` receives the
+// merged JSON array; and `maxPages` caps the number of pages fetched,
+// guarding against an unbounded result set. A `maxPages` of `0` or less uses
+// DefaultMaxStreamPages.
+//
+// Please remember to explicitly add the `ORDER` clause to the query before
+// calling this function, so that rows are returned in a stable order across
+// pages.
+func StreamAllPages[V any, Q any, T PQ[V, Q]](
+	gc *gin.Context, qc context.Context, query T, params PaginatedParams,
+	writer io.Writer, maxPages int,
+) error {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxStreamPages
+	}
+	gc.Header("Content-Type", MimeJSON)
+	perPage := params.GetPerPage()
+	if _, err := writer.Write([]byte("[")); err != nil {
+		return err
+	}
+	first := true
+	for page := 1; page <= maxPages; page++ {
+		select {
+		case <-qc.Done():
+			return qc.Err()
+		default:
+		}
+		query.Offset((page - 1) * perPage)
+		query.Limit(perPage)
+		rows, err := query.All(qc)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			b, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err = writer.Write([]byte(",")); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err = writer.Write(b); err != nil {
+				return err
+			}
+		}
+		if flusher, ok := writer.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+		if len(rows) < perPage {
+			break
+		}
+	}
+	_, err := writer.Write([]byte("]"))
+	return err
+}
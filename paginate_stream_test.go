@@ -0,0 +1,84 @@
+package paginate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestStreamAllPagesHasNextBoundary(t *testing.T) {
+	var buf bytes.Buffer
+	err := StreamAllPages[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), context.Background(),
+		newFakeQuery(4), PaginatedParams{PerPage: 2}, &buf, 0,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rows []fakeRow
+	if err = json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("streamed body is not a valid JSON array: %v\nbody: %s", err, buf.String())
+	}
+	if got, want := cursorIDs(rowPtrs(rows)), []int{1, 2, 3, 4}; !equalInts(got, want) {
+		t.Fatalf("streamed rows = %v, want %v", got, want)
+	}
+}
+
+func TestStreamAllPagesMaxPagesTruncates(t *testing.T) {
+	var buf bytes.Buffer
+	err := StreamAllPages[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), context.Background(),
+		newFakeQuery(100), PaginatedParams{PerPage: 10}, &buf, 2,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rows []fakeRow
+	if err = json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("streamed body is not a valid JSON array: %v\nbody: %s", err, buf.String())
+	}
+	if 20 != len(rows) {
+		t.Fatalf(
+			"streamed %d rows, want 20 (maxPages=2 * PerPage=10)", len(rows),
+		)
+	}
+}
+
+func TestStreamAllPagesHonorsCancellation(t *testing.T) {
+	var buf bytes.Buffer
+	qc, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := StreamAllPages[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items"), qc, newFakeQuery(4),
+		PaginatedParams{PerPage: 2}, &buf, 0,
+	)
+	if err == nil {
+		t.Fatal("expected an error from a pre-cancelled context, got nil")
+	}
+	if qc.Err() != err {
+		t.Fatalf("error = %v, want %v", err, qc.Err())
+	}
+}
+
+func TestStreamAllPagesSetsContentType(t *testing.T) {
+	var buf bytes.Buffer
+	gc := newTestGinContext("http://example.com/items")
+	if err := StreamAllPages[fakeRow, fakeQuery, *fakeQuery](
+		gc, context.Background(), newFakeQuery(2), PaginatedParams{PerPage: 2},
+		&buf, 0,
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gc.Writer.Header().Get("Content-Type"); MimeJSON != got {
+		t.Fatalf("Content-Type = %q, want %q", got, MimeJSON)
+	}
+}
+
+func rowPtrs(rows []fakeRow) []*fakeRow {
+	out := make([]*fakeRow, len(rows))
+	for i := range rows {
+		out[i] = &rows[i]
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+package paginate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeRow is a minimal row type used by the in-memory fakeQuery below, to
+// exercise GetPage/GetPageCursor/GetPageWithOptions without a real database.
+type fakeRow struct {
+	ID int
+}
+
+// fakeQuery is an in-memory, ID-ordered stand-in for an ent-style query
+// builder, implementing PQ and CursorPQ.
+type fakeQuery struct {
+	all      []*fakeRow
+	offsetN  int
+	limitN   int
+	reverse  bool
+	afterID  *int
+	beforeID *int
+	countErr error
+	allErr   error
+}
+
+func newFakeQuery(n int) *fakeQuery {
+	rows := make([]*fakeRow, n)
+	for i := 0; i < n; i++ {
+		rows[i] = &fakeRow{ID: i + 1}
+	}
+	return &fakeQuery{all: rows}
+}
+
+func (q *fakeQuery) Offset(o int) *fakeQuery {
+	q.offsetN = o
+	return q
+}
+
+func (q *fakeQuery) Limit(l int) *fakeQuery {
+	q.limitN = l
+	return q
+}
+
+func (q *fakeQuery) Count(context.Context) (int, error) {
+	if nil != q.countErr {
+		return 0, q.countErr
+	}
+	return len(q.filtered()), nil
+}
+
+func (q *fakeQuery) All(context.Context) ([]*fakeRow, error) {
+	if nil != q.allErr {
+		return nil, q.allErr
+	}
+	rows := q.filtered()
+	if q.reverse {
+		rows = reverseFakeRows(rows)
+	}
+	start := q.offsetN
+	if start > len(rows) {
+		start = len(rows)
+	}
+	rows = rows[start:]
+	if q.limitN > 0 && q.limitN < len(rows) {
+		rows = rows[:q.limitN]
+	}
+	return rows, nil
+}
+
+func (q *fakeQuery) Where(predicate func(*fakeQuery)) *fakeQuery {
+	predicate(q)
+	return q
+}
+
+func (q *fakeQuery) Reverse() *fakeQuery {
+	q.reverse = !q.reverse
+	return q
+}
+
+func (q *fakeQuery) filtered() []*fakeRow {
+	out := make([]*fakeRow, 0, len(q.all))
+	for _, r := range q.all {
+		if nil != q.afterID && r.ID <= *q.afterID {
+			continue
+		}
+		if nil != q.beforeID && r.ID >= *q.beforeID {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func reverseFakeRows(rows []*fakeRow) []*fakeRow {
+	out := make([]*fakeRow, len(rows))
+	for i, r := range rows {
+		out[len(rows)-1-i] = r
+	}
+	return out
+}
+
+// fakeCursorDescriptor returns a CursorDescriptor keyed on fakeRow.ID.
+func fakeCursorDescriptor() CursorDescriptor[fakeRow, fakeQuery] {
+	return CursorDescriptor[fakeRow, fakeQuery]{
+		Columns: []string{"id"},
+		Extract: func(r *fakeRow) []any { return []any{float64(r.ID)} },
+		After: func(values []any) func(*fakeQuery) {
+			id := int(values[0].(float64))
+			return func(q *fakeQuery) { q.afterID = &id }
+		},
+		Before: func(values []any) func(*fakeQuery) {
+			id := int(values[0].(float64))
+			return func(q *fakeQuery) { q.beforeID = &id }
+		},
+	}
+}
+
+// newTestGinContext returns a gin.Context whose Request is a GET to rawURL,
+// suitable for functions that read gc.Request to build page links.
+func newTestGinContext(rawURL string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	gc.Request = httptest.NewRequest(http.MethodGet, rawURL, nil)
+	return gc
+}
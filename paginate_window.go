@@ -0,0 +1,81 @@
+package paginate
+
+// PageLink represents a single entry in a rendered page-window pager, as
+// produced by PageWindow and PaginatedList.Pages.
+type PageLink struct {
+	// Number is the page number. It is meaningless when Ellipsis is true.
+	Number int `json:"number" bson:"number" xml:"number" yaml:"number"`
+	// Url is the URL of the page. It is empty when Ellipsis is true.
+	Url string `json:"url" bson:"url" xml:"url" yaml:"url"`
+	// Current is true when this entry is the current page.
+	Current bool `json:"current" bson:"current" xml:"current" yaml:"current"`
+	// Ellipsis is true when this entry is a gap sentinel rather than an
+	// actual page, e.g. the `…` in `1 … 6 7 [8] 9 10 … 42`.
+	Ellipsis bool `json:"ellipsis" bson:"ellipsis" xml:"ellipsis" yaml:"ellipsis"`
+}
+
+// PageWindow returns the page numbers to display in an elided pager,
+// mirroring the windowing algorithm used by Hugo and the Beego paginator:
+// page `1` and `last` are always included, `radius` pages on either side of
+// `current` are included, and a `0` sentinel marks a gap of more than one
+// page that was skipped, e.g. `[1 0 6 7 8 9 10 0 42]`.
+func PageWindow(current, last, radius int) []int {
+	if last < 1 {
+		last = 1
+	}
+	if current < 1 {
+		current = 1
+	}
+	if current > last {
+		current = last
+	}
+	if radius < 0 {
+		radius = 0
+	}
+	include := make(map[int]bool, last)
+	include[1] = true
+	include[last] = true
+	for i := current - radius; i <= current+radius; i++ {
+		if i >= 1 && i <= last {
+			include[i] = true
+		}
+	}
+	pages := make([]int, 0, last)
+	for i := 1; i <= last; i++ {
+		if include[i] {
+			pages = append(pages, i)
+		}
+	}
+	window := make([]int, 0, len(pages)+2)
+	for i, p := range pages {
+		if 0 != i && p-pages[i-1] > 1 {
+			window = append(window, 0)
+		}
+		window = append(window, p)
+	}
+	return window
+}
+
+// Pages renders this list's current page window as a slice of PageLink
+// entries, so template authors can build a pager without reimplementing
+// PageWindow's math. See PageWindow for the windowing rules.
+//
+// Links are built with UrlWithPage against the request that produced this
+// list, the same way FirstPageUrl/NextPageUrl/... are, so any non-pagination
+// query parameters (filters, sort, ...) already on the request are preserved.
+func (pl *PaginatedList[T]) Pages(radius int) []PageLink {
+	window := PageWindow(pl.CurrentPage, pl.LastPage, radius)
+	links := make([]PageLink, len(window))
+	for i, number := range window {
+		if 0 == number {
+			links[i] = PageLink{Ellipsis: true}
+			continue
+		}
+		links[i] = PageLink{
+			Number:  number,
+			Url:     UrlWithPage(pl.request, number, pl.PerPage).String(),
+			Current: number == pl.CurrentPage,
+		}
+	}
+	return links
+}
@@ -0,0 +1,89 @@
+package paginate
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPageWindow(t *testing.T) {
+	cases := []struct {
+		name                   string
+		current, last, radius int
+		want                   []int
+	}{
+		{"single page", 1, 1, 2, []int{1}},
+		{"no gaps", 3, 5, 2, []int{1, 2, 3, 4, 5}},
+		{
+			"middle with both gaps", 8, 42, 2,
+			[]int{1, 0, 6, 7, 8, 9, 10, 0, 42},
+		},
+		{"near start, right gap only", 2, 42, 2, []int{1, 2, 3, 4, 0, 42}},
+		{
+			"near end, left gap only", 41, 42, 2,
+			[]int{1, 0, 39, 40, 41, 42},
+		},
+		{"radius zero", 8, 42, 0, []int{1, 0, 8, 0, 42}},
+		{"current below 1 clamps to 1", 0, 5, 1, []int{1, 2, 0, 5}},
+		{"current above last clamps to last", 99, 5, 1, []int{1, 0, 4, 5}},
+	}
+	for _, tc := range cases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got := PageWindow(tc.current, tc.last, tc.radius)
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf(
+						"PageWindow(%d, %d, %d) = %v, want %v", tc.current,
+						tc.last, tc.radius, got, tc.want,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestPaginatedListPagesPreservesQueryParams(t *testing.T) {
+	list, err := GetPage[fakeRow, fakeQuery, *fakeQuery](
+		newTestGinContext("http://example.com/items?filter=active&page=2&per_page=2"),
+		context.Background(), newFakeQuery(5), PaginatedParams{Page: 2, PerPage: 2},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pages := list.Pages(2)
+	if 0 == len(pages) {
+		t.Fatal("Pages() returned no links")
+	}
+	var sawCurrent bool
+	for _, link := range pages {
+		if link.Ellipsis {
+			continue
+		}
+		if "" == link.Url {
+			t.Fatalf("page %d: Url is empty", link.Number)
+		}
+		parsed, err := url.Parse(link.Url)
+		if err != nil {
+			t.Fatalf("url.Parse(%q) returned error: %v", link.Url, err)
+		}
+		if "active" != parsed.Query().Get("filter") {
+			t.Fatalf(
+				"page %d url = %q, expected filter=active to be preserved",
+				link.Number, link.Url,
+			)
+		}
+		if link.Current {
+			sawCurrent = true
+			if !strings.Contains(link.Url, "page=2") {
+				t.Fatalf(
+					"current page url = %q, expected page=2", link.Url,
+				)
+			}
+		}
+	}
+	if !sawCurrent {
+		t.Fatal("Pages() did not mark any entry as Current")
+	}
+}